@@ -0,0 +1,203 @@
+package getopt
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Bind builds a set of Option values from the exported fields of the struct pointed
+// to by v and parses args with them, assigning parsed values directly into the
+// struct fields. It saves the boilerplate of writing an OptionFunc by hand for
+// every flag of a typical CLI.
+//
+// Fields are only considered if they carry a `short` and/or `long` struct tag,
+// naming the option's short and long form respectively. A `default` tag sets the
+// field's value before parsing, and a `required` tag ("true") causes Bind to
+// return an error if the option is missing from args. `default` and `required`
+// are mutually exclusive on the same field — a required option can't also have
+// a default, since one would make the other meaningless — and Bind rejects the
+// combination. A `desc` tag is copied into the Option's Description and not
+// otherwise interpreted by Bind; it exists for callers that build their own
+// usage/help text from the returned Options.
+//
+// Supported field types are bool, string, the signed and unsigned integer types,
+// float64, time.Duration, and []string. A bool field becomes a NoArgument option;
+// everything else becomes a RequiredArgument option. A []string field may be
+// repeated on the command line; each occurrence appends to the slice.
+func Bind(v interface{}, args []string) (*Getopt, error) {
+	options, requiredIndex, err := bindOptions(v)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[*Option]bool, len(options))
+	required := make(map[*Option]bool, len(requiredIndex))
+	for i := range options {
+		option := &options[i]
+		if requiredIndex[i] {
+			required[option] = true
+		}
+		handler := option.Handler
+		// Parse hands the handler a pointer to a copy of the Option, so the
+		// key recorded here is the slice element captured by the closure.
+		option.Handler = func(o *Option, nameType NameType, value string) error {
+			seen[option] = true
+			return handler(o, nameType, value)
+		}
+	}
+
+	g := &Getopt{
+		AllowAbbrev: true,
+		Options:     options,
+	}
+	if err := g.Parse(args); err != nil {
+		return nil, err
+	}
+
+	for i := range options {
+		option := &options[i]
+		if required[option] && !seen[option] {
+			return nil, fmt.Errorf("option %s is required", optionName(option))
+		}
+	}
+
+	return g, nil
+}
+
+func optionName(option *Option) string {
+	switch {
+	case option.LongName != NoLongName:
+		return "'--" + option.LongName + "'"
+	default:
+		return fmt.Sprintf("'-%c'", option.ShortName)
+	}
+}
+
+func bindOptions(v interface{}) ([]Option, map[int]bool, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("getopt: Bind requires a pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var options []Option
+	required := make(map[int]bool)
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		short, hasShort := field.Tag.Lookup("short")
+		long, hasLong := field.Tag.Lookup("long")
+		if !hasShort && !hasLong {
+			continue
+		}
+
+		var shortName rune
+		if hasShort {
+			runes := []rune(short)
+			if len(runes) != 1 {
+				return nil, nil, fmt.Errorf("getopt: field %s: short tag must be exactly one character", field.Name)
+			}
+			shortName = runes[0]
+		}
+
+		fv := rv.Field(i)
+
+		hasArg := RequiredArgument
+		if fv.Kind() == reflect.Bool {
+			hasArg = NoArgument
+		}
+
+		_, hasDefault := field.Tag.Lookup("default")
+		isRequired, _ := strconv.ParseBool(field.Tag.Get("required"))
+		if hasDefault && isRequired {
+			return nil, nil, fmt.Errorf("getopt: field %s: required and default are mutually exclusive", field.Name)
+		}
+
+		if def, ok := field.Tag.Lookup("default"); ok {
+			if err := setFieldValue(fv, def); err != nil {
+				return nil, nil, fmt.Errorf("getopt: field %s: default: %w", field.Name, err)
+			}
+		}
+
+		option := Option{
+			ShortName:   shortName,
+			LongName:    long,
+			HasArg:      hasArg,
+			Description: field.Tag.Get("desc"),
+			Handler: func(option *Option, nameType NameType, value string) error {
+				if fv.Kind() == reflect.Bool {
+					fv.SetBool(true)
+					return nil
+				}
+				if err := setFieldValue(fv, value); err != nil {
+					return fmt.Errorf("%s: %w", optionName(option), err)
+				}
+				return nil
+			},
+		}
+
+		options = append(options, option)
+
+		if isRequired {
+			required[len(options)-1] = true
+		}
+	}
+
+	return options, required, nil
+}
+
+func setFieldValue(fv reflect.Value, value string) error {
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String {
+		fv.Set(reflect.Append(fv, reflect.ValueOf(value)))
+		return nil
+	}
+
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+
+	return nil
+}
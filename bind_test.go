@@ -0,0 +1,105 @@
+package getopt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBindBasic(t *testing.T) {
+	cfg := struct {
+		Repo    string `short:"r" long:"repo"`
+		Verbose bool   `short:"v" long:"verbose"`
+		Count   int    `long:"count" default:"1"`
+	}{}
+
+	if _, err := Bind(&cfg, []string{"PROG", "-r", "module", "--verbose", "--count=3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Repo != "module" || !cfg.Verbose || cfg.Count != 3 {
+		t.Fatalf("unexpected: %#v", cfg)
+	}
+}
+
+func TestBindDefault(t *testing.T) {
+	cfg := struct {
+		Count int `long:"count" default:"5"`
+	}{}
+
+	if _, err := Bind(&cfg, []string{"PROG"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Count != 5 {
+		t.Fatalf("unexpected: %#v", cfg)
+	}
+}
+
+func TestBindRequired(t *testing.T) {
+	cfg := struct {
+		Repo string `long:"repo" required:"true"`
+	}{}
+
+	if _, err := Bind(&cfg, []string{"PROG"}); err == nil {
+		t.Fatal("expected an error for a missing required option")
+	}
+}
+
+func TestBindRequiredNotLastField(t *testing.T) {
+	cfg := struct {
+		Repo    string `long:"repo" required:"true"`
+		Verbose bool   `long:"verbose"`
+	}{}
+
+	if _, err := Bind(&cfg, []string{"PROG", "--verbose"}); err == nil {
+		t.Fatal("expected an error for a missing required option that is not the last tagged field")
+	}
+}
+
+func TestBindRequiredWithDefaultRejected(t *testing.T) {
+	cfg := struct {
+		Count int `long:"count" default:"5" required:"true"`
+	}{}
+
+	if _, err := Bind(&cfg, []string{"PROG"}); err == nil {
+		t.Fatal("expected an error for a field tagged both required and default")
+	}
+}
+
+func TestBindSliceAppends(t *testing.T) {
+	cfg := struct {
+		Tags []string `long:"tag"`
+	}{}
+
+	if _, err := Bind(&cfg, []string{"PROG", "--tag=a", "--tag=b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Tags) != 2 || cfg.Tags[0] != "a" || cfg.Tags[1] != "b" {
+		t.Fatalf("unexpected: %#v", cfg.Tags)
+	}
+}
+
+func TestBindDuration(t *testing.T) {
+	cfg := struct {
+		Timeout time.Duration `long:"timeout"`
+	}{}
+
+	if _, err := Bind(&cfg, []string{"PROG", "--timeout=1500ms"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Timeout != 1500*time.Millisecond {
+		t.Fatalf("unexpected: %#v", cfg.Timeout)
+	}
+}
+
+func TestBindNonPointer(t *testing.T) {
+	cfg := struct {
+		Repo string `long:"repo"`
+	}{}
+
+	if _, err := Bind(cfg, []string{"PROG"}); err == nil {
+		t.Fatal("expected an error for a non-pointer argument")
+	}
+}
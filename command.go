@@ -0,0 +1,126 @@
+package getopt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Command describes one node of a subcommand tree in the style of `git foo bar
+// --opt`. A Command has its own Options, parsed with a Getopt exactly as at the
+// top level, a Run handler invoked once parsing reaches this command, and a set
+// of child Commands it may delegate to.
+type Command struct {
+	// Name is the command's name as it appears on the command line.
+	Name string
+	// Short is a one-line description shown next to Name in Usage output.
+	Short string
+	// Options describes this command's own short and long options.
+	Options []Option
+	// Positional, if set, is called once for each positional argument left
+	// over after option parsing, in order, before Run is called.
+	Positional func(value string) error
+	// Run is called once option and subcommand resolution has finished, with
+	// all positional arguments left over after option parsing, including those
+	// already passed to Positional. Run may be nil for a Command that exists
+	// only to group subcommands.
+	Run func(ctx context.Context, args []string) error
+	// Commands holds this command's subcommands, keyed by name.
+	Commands map[string]*Command
+}
+
+// AddCommand registers sub as a subcommand of c.
+func (c *Command) AddCommand(sub *Command) {
+	if c.Commands == nil {
+		c.Commands = make(map[string]*Command)
+	}
+	c.Commands[sub.Name] = sub
+}
+
+// Execute parses args against c's Options and, if the first remaining positional
+// argument names one of c's Commands, recurses into it; otherwise it invokes c's
+// Positional and Run handlers. args[0] is the program name, as with
+// Getopt.Parse.
+func (c *Command) Execute(ctx context.Context, args []string) error {
+	g := Getopt{Options: c.Options}
+	if len(c.Commands) > 0 {
+		// Stop at the first positional so a subcommand's own options, which
+		// this Getopt knows nothing about, are handed to it untouched instead
+		// of being matched against c.Options and rejected as unrecognized.
+		g.Mode = ModeRequireOrder
+	}
+	if err := g.Parse(args); err != nil {
+		return err
+	}
+
+	rest := g.Args()
+
+	if len(rest) > 0 {
+		if sub, ok := c.Commands[rest[0]]; ok {
+			subArgs := append([]string{args[0]}, rest[1:]...)
+			return sub.Execute(ctx, subArgs)
+		}
+	}
+
+	if c.Positional != nil {
+		for _, value := range rest {
+			if err := c.Positional(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.Run == nil {
+		return fmt.Errorf("getopt: command '%s' requires a subcommand", c.Name)
+	}
+
+	return c.Run(ctx, rest)
+}
+
+// Usage returns hierarchical help text for c and its subcommands, indenting each
+// level of the tree by two spaces.
+func (c *Command) Usage() string {
+	var b strings.Builder
+	c.writeUsage(&b, 0)
+	return b.String()
+}
+
+func (c *Command) writeUsage(b *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	fmt.Fprintf(b, "%s%s", indent, c.Name)
+	if c.Short != "" {
+		fmt.Fprintf(b, " - %s", c.Short)
+	}
+	b.WriteString("\n")
+
+	for _, option := range c.Options {
+		fmt.Fprintf(b, "%s  %s", indent, formatOptionUsage(option))
+		if option.Description != "" {
+			fmt.Fprintf(b, "\t%s", option.Description)
+		}
+		b.WriteString("\n")
+	}
+
+	names := make([]string, 0, len(c.Commands))
+	for name := range c.Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c.Commands[name].writeUsage(b, depth+1)
+	}
+}
+
+func formatOptionUsage(option Option) string {
+	var parts []string
+	if option.ShortName != NoShortName {
+		parts = append(parts, fmt.Sprintf("-%c", option.ShortName))
+	}
+	if option.LongName != NoLongName {
+		parts = append(parts, "--"+option.LongName)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,127 @@
+package getopt
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCommandRoot(t *testing.T) {
+	var ran bool
+	cmd := &Command{
+		Name: "prog",
+		Run: func(ctx context.Context, args []string) error {
+			ran = true
+			if len(args) != 0 {
+				t.Fatalf("unexpected args: %#v", args)
+			}
+			return nil
+		},
+	}
+
+	if err := cmd.Execute(context.Background(), []string{"prog"}); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Fatal("root command was not run")
+	}
+}
+
+func TestCommandSubcommand(t *testing.T) {
+	var ranSub bool
+	var gotArgs []string
+
+	root := &Command{Name: "prog"}
+	root.AddCommand(&Command{
+		Name: "push",
+		Run: func(ctx context.Context, args []string) error {
+			ranSub = true
+			gotArgs = args
+			return nil
+		},
+	})
+
+	if err := root.Execute(context.Background(), []string{"prog", "push", "origin"}); err != nil {
+		t.Fatal(err)
+	}
+	if !ranSub {
+		t.Fatal("subcommand was not run")
+	}
+	if len(gotArgs) != 1 || gotArgs[0] != "origin" {
+		t.Fatalf("unexpected args: %#v", gotArgs)
+	}
+}
+
+func TestCommandSubcommandOwnOption(t *testing.T) {
+	var force bool
+	var ranSub bool
+
+	root := &Command{Name: "prog"}
+	root.AddCommand(&Command{
+		Name: "push",
+		Options: []Option{
+			{LongName: "force", Handler: func(*Option, NameType, string) error {
+				force = true
+				return nil
+			}},
+		},
+		Run: func(ctx context.Context, args []string) error {
+			ranSub = true
+			return nil
+		},
+	})
+
+	if err := root.Execute(context.Background(), []string{"prog", "push", "--force"}); err != nil {
+		t.Fatal(err)
+	}
+	if !force || !ranSub {
+		t.Fatalf("force=%v ranSub=%v", force, ranSub)
+	}
+}
+
+func TestCommandOptionsBeforeSubcommand(t *testing.T) {
+	var verbose bool
+	var ranSub bool
+
+	root := &Command{
+		Name: "prog",
+		Options: []Option{
+			{ShortName: 'v', LongName: "verbose", Handler: func(*Option, NameType, string) error {
+				verbose = true
+				return nil
+			}},
+		},
+	}
+	root.AddCommand(&Command{
+		Name: "push",
+		Run: func(ctx context.Context, args []string) error {
+			ranSub = true
+			return nil
+		},
+	})
+
+	if err := root.Execute(context.Background(), []string{"prog", "-v", "push"}); err != nil {
+		t.Fatal(err)
+	}
+	if !verbose || !ranSub {
+		t.Fatalf("verbose=%v ranSub=%v", verbose, ranSub)
+	}
+}
+
+func TestCommandNoRunNoSubcommand(t *testing.T) {
+	cmd := &Command{Name: "prog"}
+
+	if err := cmd.Execute(context.Background(), []string{"prog"}); err == nil {
+		t.Fatal("expected an error when no Run handler and no subcommand match")
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	root := &Command{Name: "prog", Short: "does things"}
+	root.AddCommand(&Command{Name: "push", Short: "push changes"})
+
+	usage := root.Usage()
+	if !strings.Contains(usage, "prog - does things") || !strings.Contains(usage, "push - push changes") {
+		t.Fatalf("unexpected usage: %q", usage)
+	}
+}
@@ -0,0 +1,144 @@
+package getopt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Completion describes a single shell completion candidate: the text to insert
+// and an optional human-readable description shown alongside it by shells that
+// support it (zsh, fish).
+type Completion struct {
+	Item        string
+	Description string
+}
+
+// ValueCompleter returns completion candidates for an option's argument, given
+// whatever the user has typed of it so far.
+type ValueCompleter func(prefix string) []Completion
+
+// Complete returns completion candidates for the word at position cword in args,
+// following the same conventions as go-flags and similar libraries: args holds
+// the full command line being completed (args[0] is the program name, as in
+// Parse), and cword is the index of the word under the cursor.
+//
+// If the word being completed starts with "--", long option names starting with
+// the rest of the word are returned; AllowAbbrev does not change this. If it
+// starts with a single "-", short option names are returned, or, when
+// AllowAlternative is set, long option names as for "--". Otherwise, if the previous word is an option that expects
+// an argument and has a ValueCompleter, that completer's candidates are
+// returned. In all other cases Complete returns nil.
+func (g Getopt) Complete(args []string, cword int) []Completion {
+	if cword <= 0 || cword >= len(args) {
+		return nil
+	}
+
+	word := args[cword]
+
+	if strings.HasPrefix(word, "--") || (g.AllowAlternative && strings.HasPrefix(word, "-")) {
+		return g.completeLongNames(strings.TrimLeft(word, "-"))
+	}
+
+	if strings.HasPrefix(word, "-") {
+		return g.completeShortNames()
+	}
+
+	if option := g.optionExpectingArg(args, cword); option != nil && option.ValueCompleter != nil {
+		return option.ValueCompleter(word)
+	}
+
+	return nil
+}
+
+func (g Getopt) completeLongNames(prefix string) []Completion {
+	var out []Completion
+	for _, option := range g.Options {
+		if option.LongName == NoLongName {
+			continue
+		}
+		if strings.HasPrefix(option.LongName, prefix) {
+			out = append(out, Completion{Item: "--" + option.LongName, Description: option.Description})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Item < out[j].Item })
+	return out
+}
+
+func (g Getopt) completeShortNames() []Completion {
+	var out []Completion
+	for _, option := range g.Options {
+		if option.ShortName == NoShortName {
+			continue
+		}
+		out = append(out, Completion{Item: fmt.Sprintf("-%c", option.ShortName), Description: option.Description})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Item < out[j].Item })
+	return out
+}
+
+// optionExpectingArg looks at the word immediately before cword and, if it names
+// an option that takes an argument not yet supplied, returns that option.
+func (g Getopt) optionExpectingArg(args []string, cword int) *Option {
+	prev := args[cword-1]
+
+	switch {
+	case strings.HasPrefix(prev, "--"):
+		name := strings.TrimPrefix(prev, "--")
+		if i := strings.IndexByte(name, '='); i >= 0 {
+			return nil
+		}
+		option, err := g.getLongOption(name, g.Options)
+		if err != nil || option.HasArg == NoArgument {
+			return nil
+		}
+		return option
+	case strings.HasPrefix(prev, "-") && len(prev) > 1:
+		runes := []rune(prev[1:])
+		option, err := g.getShortOption(runes[len(runes)-1], g.Options)
+		if err != nil || option == nil || option.HasArg == NoArgument {
+			return nil
+		}
+		return option
+	}
+
+	return nil
+}
+
+// CompleteBash returns a bash completion script that calls back into the
+// program (via name) with a hidden "--getopt-complete" mode to enumerate
+// candidates. The script offers whatever candidates the program prints,
+// filtered by the current word; when there are none, it does not fall back to
+// filename completion.
+func CompleteBash(name string) string {
+	return fmt.Sprintf(`_%[1]s_complete() {
+	local cur prev
+	COMPREPLY=()
+	cur="${COMP_WORDS[COMP_CWORD]}"
+	prev="${COMP_WORDS[COMP_CWORD-1]}"
+	local candidates
+	candidates=$(%[1]s --getopt-complete "${COMP_CWORD}" "${COMP_WORDS[@]}")
+	COMPREPLY=($(compgen -W "${candidates}" -- "${cur}"))
+}
+complete -F _%[1]s_complete %[1]s
+`, name)
+}
+
+// CompleteZsh returns a zsh completion script analogous to CompleteBash, but
+// using _describe so option descriptions are shown alongside each candidate.
+func CompleteZsh(name string) string {
+	return fmt.Sprintf(`#compdef %[1]s
+_%[1]s() {
+	local -a candidates
+	candidates=(${(f)"$(%[1]s --getopt-complete "$((CURRENT - 1))" "${words[@]}")"})
+	_describe '%[1]s' candidates
+}
+_%[1]s
+`, name)
+}
+
+// CompleteFish returns a fish completion script analogous to CompleteBash.
+func CompleteFish(name string) string {
+	return fmt.Sprintf(`complete -c %[1]s -f -a '(%[1]s --getopt-complete (count (commandline -poc)) (commandline -poc) (commandline -ct | string collect -a))'
+`, name)
+}
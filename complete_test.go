@@ -0,0 +1,55 @@
+package getopt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func completeTestGetopt() *Getopt {
+	return &Getopt{
+		AllowAbbrev: true,
+		Options: []Option{
+			{ShortName: 'h', LongName: "help", HasArg: NoArgument},
+			{ShortName: 'o', LongName: "output", HasArg: RequiredArgument,
+				ValueCompleter: func(prefix string) []Completion {
+					return []Completion{{Item: "json"}, {Item: "yaml"}}
+				}},
+			{ShortName: NoShortName, LongName: "verbose", HasArg: NoArgument},
+		},
+	}
+}
+
+func TestCompleteLongName(t *testing.T) {
+	g := completeTestGetopt()
+	got := g.Complete([]string{"PROG", "--ve"}, 1)
+	want := []Completion{{Item: "--verbose"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected: %#v", got)
+	}
+}
+
+func TestCompleteShortName(t *testing.T) {
+	g := completeTestGetopt()
+	got := g.Complete([]string{"PROG", "-"}, 1)
+	want := []Completion{{Item: "-h"}, {Item: "-o"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected: %#v", got)
+	}
+}
+
+func TestCompleteValue(t *testing.T) {
+	g := completeTestGetopt()
+	got := g.Complete([]string{"PROG", "--output", ""}, 2)
+	want := []Completion{{Item: "json"}, {Item: "yaml"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected: %#v", got)
+	}
+}
+
+func TestCompleteNoValueCompleter(t *testing.T) {
+	g := completeTestGetopt()
+	got := g.Complete([]string{"PROG", "--help", ""}, 2)
+	if got != nil {
+		t.Fatalf("unexpected: %#v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package getopt
+
+import "fmt"
+
+// UnknownOptionError is returned by Parse when the command line names a short or
+// long option that is not present in Getopt.Options. Exactly one of Short and
+// Long is set, matching the form the option was given in.
+type UnknownOptionError struct {
+	Short rune
+	Long  string
+}
+
+func (e *UnknownOptionError) Error() string {
+	if e.Long != NoLongName {
+		return fmt.Sprintf("unrecognized option -- '%s'", e.Long)
+	}
+	return fmt.Sprintf("invalid option -- '%c'", e.Short)
+}
+
+// AmbiguousOptionError is returned by Parse when AllowAbbrev is set and an
+// abbreviated long option name matches more than one Option.
+type AmbiguousOptionError struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousOptionError) Error() string {
+	msg := fmt.Sprintf("option '--%s' is ambiguous; possibilities:", e.Name)
+	for _, c := range e.Candidates {
+		msg += fmt.Sprintf(" '--%s'", c)
+	}
+	return msg
+}
+
+// MissingArgumentError is returned by Parse when an option requiring an argument
+// is given without one. Exactly one of Short and Long is set, matching the form
+// the option was given in.
+type MissingArgumentError struct {
+	Short rune
+	Long  string
+}
+
+func (e *MissingArgumentError) Error() string {
+	if e.Long != NoLongName {
+		return fmt.Sprintf("option '--%s' requires an argument", e.Long)
+	}
+	return fmt.Sprintf("option requires an argument -- '%c'", e.Short)
+}
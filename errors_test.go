@@ -0,0 +1,70 @@
+package getopt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnknownShortOptionError(t *testing.T) {
+	g := &Getopt{Options: []Option{{ShortName: 'h', Handler: func(*Option, NameType, string) error { return nil }}}}
+
+	err := g.Parse([]string{"PROG", "-z"})
+
+	var unknown *UnknownOptionError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected *UnknownOptionError, got %#v", err)
+	}
+	if unknown.Short != 'z' {
+		t.Fatalf("unexpected: %#v", unknown)
+	}
+}
+
+func TestUnknownLongOptionError(t *testing.T) {
+	g := &Getopt{Options: []Option{{LongName: "help", Handler: func(*Option, NameType, string) error { return nil }}}}
+
+	err := g.Parse([]string{"PROG", "--zzz"})
+
+	var unknown *UnknownOptionError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected *UnknownOptionError, got %#v", err)
+	}
+	if unknown.Long != "zzz" {
+		t.Fatalf("unexpected: %#v", unknown)
+	}
+}
+
+func TestAmbiguousOptionError(t *testing.T) {
+	noop := func(*Option, NameType, string) error { return nil }
+	g := &Getopt{
+		AllowAbbrev: true,
+		Options: []Option{
+			{LongName: "caa", Handler: noop},
+			{LongName: "cba", Handler: noop},
+		},
+	}
+
+	err := g.Parse([]string{"PROG", "--c"})
+
+	var ambiguous *AmbiguousOptionError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected *AmbiguousOptionError, got %#v", err)
+	}
+	if ambiguous.Name != "c" || len(ambiguous.Candidates) != 2 {
+		t.Fatalf("unexpected: %#v", ambiguous)
+	}
+}
+
+func TestMissingArgumentError(t *testing.T) {
+	noop := func(*Option, NameType, string) error { return nil }
+	g := &Getopt{Options: []Option{{ShortName: 'b', HasArg: RequiredArgument, Handler: noop}}}
+
+	err := g.Parse([]string{"PROG", "-b"})
+
+	var missing *MissingArgumentError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected *MissingArgumentError, got %#v", err)
+	}
+	if missing.Short != 'b' {
+		t.Fatalf("unexpected: %#v", missing)
+	}
+}
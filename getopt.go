@@ -21,8 +21,9 @@
 package getopt
 
 import (
-	"fmt"
+	"os"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -52,8 +53,9 @@ const (
 
 // Option describes command-line option, his short and long form.
 type Option struct {
-	// ShortName specifies short form of the option. If there is no such form, it should be NoShortName.
-	ShortName byte
+	// ShortName specifies short form of the option as a Unicode code point, so it is
+	// not limited to ASCII. If there is no such form, it should be NoShortName.
+	ShortName rune
 	// LongName specifies long form of the option. If there is no such form, it should be NoLongName.
 	LongName string
 	// HasArg describes the need to have the argument. Option may not require additional arguments (NoArgument),
@@ -61,21 +63,68 @@ type Option struct {
 	HasArg ArgumentType
 	// Handler specifies the handler that will be called if the option is specified on the command line.
 	Handler OptionFunc
+	// Description is a short, human-readable description of the option, used by help
+	// and shell completion output. It has no effect on parsing.
+	Description string
+	// ValueCompleter, if set, is used by Getopt.Complete to suggest values for this
+	// option's argument. It has no effect on parsing.
+	ValueCompleter ValueCompleter
 }
 
 type OptionFunc func(*Option, NameType, string) error
 
+// Mode selects how Parse treats non-option arguments, matching the modes of GNU
+// getopt(3).
+type Mode int
+
+const (
+	// ModeDefault, the zero value, makes Parse behave as ModePermute unless the
+	// POSIXLY_CORRECT environment variable is set, in which case it behaves as
+	// ModeRequireOrder.
+	ModeDefault Mode = iota
+	// ModePermute collects non-option arguments into Args(), as if they had all
+	// been moved to the end of the command line, so options and positional
+	// arguments may be freely interleaved.
+	ModePermute
+	// ModeRequireOrder stops option processing at the first non-option
+	// argument; it and everything after it are appended to Args() verbatim,
+	// without being interpreted as options. Useful for wrappers like
+	// `env PROG ...` that must not touch the wrapped command's own flags.
+	ModeRequireOrder
+	// ModeInOrder delivers every non-option argument to PositionalHandler as
+	// soon as it is encountered, interleaved with option handlers in true
+	// argv order, in addition to collecting it into Args(). Useful for tools
+	// like `find` where the position of a non-option argument relative to the
+	// options around it is significant.
+	ModeInOrder
+)
+
 type Getopt struct {
 	// AllowAlternative allows long options to start with a single `-'. See (getopt -a).
 	AllowAlternative bool
 	// AllowAbbrev allows long options be abbreviated, as long as the abbreviation is not ambiguous.
 	AllowAbbrev bool
+	// Mode selects how non-option arguments are treated. See the Mode* constants.
+	Mode Mode
+	// PositionalHandler, if set, is called for each non-option argument as it is
+	// encountered, when Mode is ModeInOrder. It is ignored in other modes.
+	PositionalHandler func(value string) error
 	// Options describes short and long options.
 	Options []Option
 	args    []string
 }
 
-func (g Getopt) getShortOption(c byte, options []Option) (*Option, error) {
+func (g Getopt) mode() Mode {
+	if g.Mode != ModeDefault {
+		return g.Mode
+	}
+	if _, ok := os.LookupEnv("POSIXLY_CORRECT"); ok {
+		return ModeRequireOrder
+	}
+	return ModePermute
+}
+
+func (g Getopt) getShortOption(c rune, options []Option) (*Option, error) {
 	for _, option := range options {
 		if option.ShortName == NoShortName {
 			continue
@@ -87,7 +136,7 @@ func (g Getopt) getShortOption(c byte, options []Option) (*Option, error) {
 	if g.AllowAlternative {
 		return nil, nil
 	}
-	return nil, fmt.Errorf("invalid option -- '%c'", c)
+	return nil, &UnknownOptionError{Short: c}
 }
 
 func (g Getopt) getLongOption(name string, options []Option) (*Option, error) {
@@ -100,7 +149,7 @@ func (g Getopt) getLongOption(name string, options []Option) (*Option, error) {
 		if g.AllowAbbrev {
 			if strings.HasPrefix(option.LongName, name) {
 				if ret != nil {
-					return nil, fmt.Errorf("option '--%s' is ambiguous; possibilities: '--%s' '--%s'", name, ret.LongName, option.LongName)
+					return nil, &AmbiguousOptionError{Name: name, Candidates: []string{ret.LongName, option.LongName}}
 				}
 				ret = &option
 			}
@@ -114,7 +163,7 @@ func (g Getopt) getLongOption(name string, options []Option) (*Option, error) {
 	if ret != nil {
 		return ret, nil
 	}
-	return nil, fmt.Errorf("unrecognized option -- '%s'", name)
+	return nil, &UnknownOptionError{Long: name}
 }
 
 func (g Getopt) splitArg(s string) (int, string, string) {
@@ -130,6 +179,8 @@ func (g Getopt) Args() []string {
 }
 
 func (g *Getopt) Parse(args []string) error {
+	mode := g.mode()
+
 	optind := 1
 	for ; optind < len(args); optind++ {
 		if args[optind] == "--" {
@@ -159,7 +210,7 @@ func (g *Getopt) Parse(args []string) error {
 			)
 			eq, args[optind], v = g.splitArg(args[optind])
 			for i, n = range args[optind][1:] {
-				option, err = g.getShortOption(byte(n), g.Options)
+				option, err = g.getShortOption(n, g.Options)
 				if err != nil {
 					return err
 				} else if option == nil {
@@ -171,7 +222,7 @@ func (g *Getopt) Parse(args []string) error {
 					goto longArg
 				}
 				if option.HasArg != NoArgument {
-					i++
+					i += utf8.RuneLen(n)
 					break
 				}
 				if err := option.Handler(option, ShortName, ""); err != nil {
@@ -207,7 +258,7 @@ func (g *Getopt) Parse(args []string) error {
 			}
 
 			if option.HasArg == RequiredArgument {
-				return fmt.Errorf("option requires an argument -- '%c'", option.ShortName)
+				return &MissingArgumentError{Short: option.ShortName}
 			}
 			if err := option.Handler(option, ShortName, ""); err != nil {
 				return err
@@ -240,7 +291,7 @@ func (g *Getopt) Parse(args []string) error {
 			}
 
 			if option.HasArg == RequiredArgument {
-				return fmt.Errorf("option '--%s' requires an argument", option.LongName)
+				return &MissingArgumentError{Long: option.LongName}
 			}
 			if err := option.Handler(option, LongName, ""); err != nil {
 				return err
@@ -248,10 +299,28 @@ func (g *Getopt) Parse(args []string) error {
 			continue
 		}
 
+		if mode == ModeRequireOrder {
+			for ; optind < len(args); optind++ {
+				g.args = append(g.args, args[optind])
+			}
+			return nil
+		}
+
+		if mode == ModeInOrder && g.PositionalHandler != nil {
+			if err := g.PositionalHandler(args[optind]); err != nil {
+				return err
+			}
+		}
+
 		g.args = append(g.args, args[optind])
 	}
 
 	for optind++; optind < len(args); optind++ {
+		if mode == ModeInOrder && g.PositionalHandler != nil {
+			if err := g.PositionalHandler(args[optind]); err != nil {
+				return err
+			}
+		}
 		g.args = append(g.args, args[optind])
 	}
 
@@ -24,12 +24,12 @@ func testCaseOne(t *testing.T, osArgs []string, expectArr []string) {
 
 	getopt := &Getopt{
 		Options: []Option{
-			{'x', NoLongName, NoArgument, optHandler},
-			{NoShortName, "xyz", NoArgument, optHandler},
-			{'h', "help", NoArgument, optHandler},
-			{'V', "version", NoArgument, optHandler},
-			{'a', "caa", NoArgument, optHandler},
-			{'b', "cba", RequiredArgument, optHandler},
+			{ShortName: 'x', LongName: NoLongName, HasArg: NoArgument, Handler: optHandler},
+			{ShortName: NoShortName, LongName: "xyz", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'h', LongName: "help", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'V', LongName: "version", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'a', LongName: "caa", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'b', LongName: "cba", HasArg: RequiredArgument, Handler: optHandler},
 		},
 	}
 
@@ -67,10 +67,10 @@ func testCaseAbbrev(t *testing.T, osArgs []string, expectArr []string) {
 	getopt := &Getopt{
 		AllowAbbrev: true,
 		Options: []Option{
-			{'h', "help", NoArgument, optHandler},
-			{'V', "version", NoArgument, optHandler},
-			{'a', "daa", NoArgument, optHandler},
-			{'b', "cba", RequiredArgument, optHandler},
+			{ShortName: 'h', LongName: "help", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'V', LongName: "version", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'a', LongName: "daa", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'b', LongName: "cba", HasArg: RequiredArgument, Handler: optHandler},
 		},
 	}
 
@@ -108,10 +108,10 @@ func testCaseAlternative(t *testing.T, osArgs []string, expectArr []string) {
 	getopt := &Getopt{
 		AllowAlternative: true,
 		Options: []Option{
-			{'h', "help", NoArgument, optHandler},
-			{'V', "version", NoArgument, optHandler},
-			{'a', "daa", NoArgument, optHandler},
-			{'b', "cba", RequiredArgument, optHandler},
+			{ShortName: 'h', LongName: "help", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'V', LongName: "version", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'a', LongName: "daa", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'b', LongName: "cba", HasArg: RequiredArgument, Handler: optHandler},
 		},
 	}
 
@@ -149,10 +149,10 @@ func testCaseOptional(t *testing.T, osArgs []string, expectArr []string) {
 	getopt := &Getopt{
 		AllowAbbrev: true,
 		Options: []Option{
-			{'h', "help", NoArgument, optHandler},
-			{'V', "version", NoArgument, optHandler},
-			{'a', "daa", NoArgument, optHandler},
-			{'b', "cba", OptionalArgument, optHandler},
+			{ShortName: 'h', LongName: "help", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'V', LongName: "version", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'a', LongName: "daa", HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'b', LongName: "cba", HasArg: OptionalArgument, Handler: optHandler},
 		},
 	}
 
@@ -322,3 +322,65 @@ func TestOptionalShortOptionTwo(t *testing.T) {
 		[]string{"-b", "{-v}", "--"},
 	)
 }
+
+func testCaseUnicode(t *testing.T, osArgs []string, expectArr []string) {
+	resultArr := []string{}
+
+	optHandler := func(option *Option, nametype NameType, value string) error {
+		switch nametype {
+		case ShortName:
+			resultArr = append(resultArr, fmt.Sprintf("-%c", option.ShortName))
+		case LongName:
+			resultArr = append(resultArr, fmt.Sprintf("--%s", option.LongName))
+		}
+		if option.HasArg != NoArgument {
+			resultArr = append(resultArr, fmt.Sprintf("{%s}", value))
+		}
+		return nil
+	}
+
+	getopt := &Getopt{
+		Options: []Option{
+			{ShortName: 'ß', LongName: NoLongName, HasArg: NoArgument, Handler: optHandler},
+			{ShortName: 'λ', LongName: NoLongName, HasArg: RequiredArgument, Handler: optHandler},
+		},
+	}
+
+	if err := getopt.Parse(osArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	resultArr = append(resultArr, "--")
+
+	for _, arg := range getopt.Args() {
+		resultArr = append(resultArr, fmt.Sprintf("{%s}", arg))
+	}
+
+	if !reflect.DeepEqual(expectArr, resultArr) {
+		t.Fatalf("unexpected: %#v", resultArr)
+	}
+}
+
+func TestUnicodeShortOption(t *testing.T) {
+	testCaseUnicode(
+		t,
+		[]string{"PROG", "-ß"},
+		[]string{"-ß", "--"},
+	)
+}
+
+func TestUnicodeShortOptionCluster(t *testing.T) {
+	testCaseUnicode(
+		t,
+		[]string{"PROG", "-ßλXXX"},
+		[]string{"-ß", "-λ", "{XXX}", "--"},
+	)
+}
+
+func TestUnicodeShortOptionWithStandaloneArgument(t *testing.T) {
+	testCaseUnicode(
+		t,
+		[]string{"PROG", "-λ", "XXX"},
+		[]string{"-λ", "{XXX}", "--"},
+	)
+}
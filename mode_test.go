@@ -0,0 +1,136 @@
+package getopt
+
+import (
+	"os"
+	"testing"
+)
+
+func modeTestOptions(t *testing.T, resultArr *[]string) []Option {
+	optHandler := func(option *Option, nametype NameType, value string) error {
+		switch nametype {
+		case ShortName:
+			*resultArr = append(*resultArr, "-"+string(option.ShortName))
+		case LongName:
+			*resultArr = append(*resultArr, "--"+option.LongName)
+		}
+		return nil
+	}
+	return []Option{
+		{ShortName: 'a', Handler: optHandler},
+		{ShortName: 'b', Handler: optHandler},
+	}
+}
+
+func TestModePermuteIsDefault(t *testing.T) {
+	var events []string
+	g := &Getopt{Options: modeTestOptions(t, &events)}
+
+	if err := g.Parse([]string{"PROG", "-a", "XXX", "-b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 || g.Args()[0] != "XXX" {
+		t.Fatalf("unexpected: events=%#v args=%#v", events, g.Args())
+	}
+}
+
+func TestModeRequireOrder(t *testing.T) {
+	var events []string
+	g := &Getopt{Mode: ModeRequireOrder, Options: modeTestOptions(t, &events)}
+
+	if err := g.Parse([]string{"PROG", "-a", "XXX", "-b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 || events[0] != "-a" {
+		t.Fatalf("unexpected events: %#v", events)
+	}
+	if want := []string{"XXX", "-b"}; !stringSlicesEqual(g.Args(), want) {
+		t.Fatalf("unexpected args: %#v", g.Args())
+	}
+}
+
+func TestModeInOrder(t *testing.T) {
+	var events []string
+	var positionals []string
+
+	g := &Getopt{
+		Mode:    ModeInOrder,
+		Options: modeTestOptions(t, &events),
+		PositionalHandler: func(value string) error {
+			positionals = append(positionals, value)
+			return nil
+		},
+	}
+
+	if err := g.Parse([]string{"PROG", "-a", "XXX", "-b", "YYY"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !stringSlicesEqual(positionals, []string{"XXX", "YYY"}) {
+		t.Fatalf("unexpected positionals: %#v", positionals)
+	}
+	if !stringSlicesEqual(g.Args(), []string{"XXX", "YYY"}) {
+		t.Fatalf("unexpected args: %#v", g.Args())
+	}
+}
+
+func TestModeInOrderAfterDoubleDash(t *testing.T) {
+	var events []string
+	var positionals []string
+
+	g := &Getopt{
+		Mode:    ModeInOrder,
+		Options: modeTestOptions(t, &events),
+		PositionalHandler: func(value string) error {
+			positionals = append(positionals, value)
+			return nil
+		},
+	}
+
+	if err := g.Parse([]string{"PROG", "-a", "foo", "--", "bar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !stringSlicesEqual(positionals, []string{"foo", "bar"}) {
+		t.Fatalf("unexpected positionals: %#v", positionals)
+	}
+	if !stringSlicesEqual(g.Args(), []string{"foo", "bar"}) {
+		t.Fatalf("unexpected args: %#v", g.Args())
+	}
+}
+
+func TestModePosixlyCorrectEnv(t *testing.T) {
+	old, had := os.LookupEnv("POSIXLY_CORRECT")
+	os.Setenv("POSIXLY_CORRECT", "1")
+	defer func() {
+		if had {
+			os.Setenv("POSIXLY_CORRECT", old)
+		} else {
+			os.Unsetenv("POSIXLY_CORRECT")
+		}
+	}()
+
+	var events []string
+	g := &Getopt{Options: modeTestOptions(t, &events)}
+
+	if err := g.Parse([]string{"PROG", "-a", "XXX", "-b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !stringSlicesEqual(g.Args(), []string{"XXX", "-b"}) {
+		t.Fatalf("unexpected args: %#v", g.Args())
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}